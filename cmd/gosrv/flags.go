@@ -0,0 +1,16 @@
+package main
+
+import "strings"
+
+// stringSliceFlag implements flag.Value, accumulating each -flag value into
+// a slice so the flag can be repeated on the command line.
+type stringSliceFlag []string
+
+func (s *stringSliceFlag) String() string {
+	return strings.Join(*s, ",")
+}
+
+func (s *stringSliceFlag) Set(v string) error {
+	*s = append(*s, v)
+	return nil
+}
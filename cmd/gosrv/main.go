@@ -0,0 +1,147 @@
+// Command gosrv is a development server with live reload: it serves a
+// directory over HTTP and pushes browser reloads when files change.
+package main
+
+import (
+	"context"
+	"flag"
+	"html/template"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/sglmr/gosrv/livereload"
+)
+
+var (
+	addr              = flag.String("addr", ":8080", "HTTP service address")
+	directory         = flag.String("dir", "./", "Directory to serve")
+	poll              = flag.Bool("poll", false, "Use polling instead of fsnotify to watch for changes (for filesystems where inotify is unavailable)")
+	buildCmd          = flag.String("build", "", "Shell command to run as a build step before serving; on failure an error overlay is shown until the next successful build")
+	errorTemplatePath = flag.String("error-template", "", "Path to a custom error overlay HTML template (receives the build error message as {{.}})")
+	certFile          = flag.String("cert", "", "TLS certificate file (together with -key, enables HTTPS)")
+	keyFile           = flag.String("key", "", "TLS key file (together with -cert, enables HTTPS)")
+	useTLS            = flag.Bool("tls", false, "Serve over HTTPS, generating and caching a self-signed cert if -cert/-key aren't given")
+	spa               = flag.Bool("spa", false, "Serve index.html with a 200 for any request that doesn't match a file on disk, for client-routed apps")
+	rewritesPath      = flag.String("rewrites", "", "Path to a rewrite rules file, one \"from -> to [status]\" rule per line")
+	ignorePatterns    stringSliceFlag
+	includePatterns   stringSliceFlag
+)
+
+func init() {
+	flag.Var(&ignorePatterns, "ignore", "Gitignore-style pattern to ignore (repeatable); .gitignore and .gosrvignore in -dir are loaded automatically")
+	flag.Var(&includePatterns, "include", "Gitignore-style pattern that overrides ignores (repeatable), e.g. to watch a single gitignored subtree")
+}
+
+func main() {
+	flag.Parse()
+
+	// Resolve absolute path
+	absDir, err := filepath.Abs(*directory)
+	if err != nil {
+		log.Fatal("Failed to resolve directory path:", err)
+	}
+
+	opts := []livereload.Option{livereload.WithDir(absDir)}
+
+	if *poll {
+		opts = append(opts, livereload.WithPollInterval(500*time.Millisecond))
+	}
+
+	if *buildCmd != "" {
+		opts = append(opts, livereload.WithBuild(livereload.ShellBuildFunc(*buildCmd)))
+	}
+
+	if len(ignorePatterns) > 0 {
+		opts = append(opts, livereload.WithIgnore(ignorePatterns...))
+	}
+	if len(includePatterns) > 0 {
+		opts = append(opts, livereload.WithInclude(includePatterns...))
+	}
+
+	if *errorTemplatePath != "" {
+		content, err := os.ReadFile(*errorTemplatePath)
+		if err != nil {
+			log.Fatal("Failed to read error template:", err)
+		}
+		tmpl, err := template.New("error").Parse(string(content))
+		if err != nil {
+			log.Fatal("Failed to parse error template:", err)
+		}
+		opts = append(opts, livereload.WithErrorTemplate(tmpl))
+	}
+
+	if *spa {
+		opts = append(opts, livereload.WithSPA(true))
+	}
+
+	if *rewritesPath != "" {
+		content, err := os.ReadFile(*rewritesPath)
+		if err != nil {
+			log.Fatal("Failed to read rewrites file:", err)
+		}
+		rules, err := livereload.ParseRewriteRules(string(content))
+		if err != nil {
+			log.Fatal("Failed to parse rewrites file:", err)
+		}
+		opts = append(opts, livereload.WithRewrites(rules...))
+	}
+
+	srv := livereload.New(opts...)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		if err := srv.Watch(ctx); err != nil {
+			log.Fatal("Watch:", err)
+		}
+	}()
+
+	mux := http.NewServeMux()
+	mux.Handle("/", srv.Handler())
+	mux.Handle("/events", srv.EventsHandler())
+
+	httpServer := &http.Server{Addr: *addr, Handler: mux}
+
+	// Serve over HTTPS if a cert/key were given, or -tls asks for a
+	// self-signed one.
+	certPath, keyPath := *certFile, *keyFile
+	serveTLS := *useTLS || (certPath != "" && keyPath != "")
+
+	if serveTLS && (certPath == "" || keyPath == "") {
+		dir, err := selfSignedCertDir()
+		if err != nil {
+			log.Fatal("Failed to resolve cert cache dir:", err)
+		}
+		certPath, keyPath, err = ensureSelfSignedCert(dir)
+		if err != nil {
+			log.Fatal("Failed to generate self-signed cert:", err)
+		}
+	}
+
+	scheme := "http"
+	if serveTLS {
+		scheme = "https"
+		fingerprint, err := certFingerprint(certPath)
+		if err != nil {
+			log.Fatal("Failed to read cert fingerprint:", err)
+		}
+		log.Printf("TLS certificate SHA-256 fingerprint: %s", fingerprint)
+	}
+
+	// Start the server
+	log.Printf("Starting development server at %s://localhost%s serving directory %s", scheme, *addr, absDir)
+	log.Printf("Press Ctrl+C to stop")
+
+	if serveTLS {
+		err = httpServer.ListenAndServeTLS(certPath, keyPath)
+	} else {
+		err = httpServer.ListenAndServe()
+	}
+	if err != nil {
+		log.Fatal("ListenAndServe:", err)
+	}
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestEnsureSelfSignedCertReusesExisting(t *testing.T) {
+	dir := t.TempDir()
+
+	certPath1, keyPath1, err := ensureSelfSignedCert(dir)
+	if err != nil {
+		t.Fatalf("ensureSelfSignedCert() error = %v", err)
+	}
+
+	fp1, err := certFingerprint(certPath1)
+	if err != nil {
+		t.Fatalf("certFingerprint() error = %v", err)
+	}
+
+	certPath2, keyPath2, err := ensureSelfSignedCert(dir)
+	if err != nil {
+		t.Fatalf("ensureSelfSignedCert() second call error = %v", err)
+	}
+	if certPath1 != certPath2 || keyPath1 != keyPath2 {
+		t.Errorf("expected ensureSelfSignedCert to return the same paths, got (%s, %s) and (%s, %s)", certPath1, keyPath1, certPath2, keyPath2)
+	}
+
+	fp2, err := certFingerprint(certPath2)
+	if err != nil {
+		t.Fatalf("certFingerprint() second call error = %v", err)
+	}
+	if fp1 != fp2 {
+		t.Errorf("expected cert to be reused, got different fingerprints %s != %s", fp1, fp2)
+	}
+}
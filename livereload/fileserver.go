@@ -0,0 +1,199 @@
+package livereload
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// liveReloadScript is the JavaScript injected into served HTML (and the
+// error overlay) that opens the EventSource connection and acts on reload
+// events.
+const liveReloadScript = `
+<script>
+    (function() {
+        const scheme = window.location.protocol === 'https:' ? 'https:' : 'http:';
+        const evtSource = new EventSource(scheme + '//' + window.location.host + '/events');
+
+        evtSource.addEventListener('connected', function(e) {
+            console.log('Live reload connected');
+        });
+
+        evtSource.addEventListener('reload', function(e) {
+            var data = JSON.parse(e.data);
+            console.log('Live reload triggered:', data);
+
+            if (data.kind === 'css' && data.path) {
+                var links = document.querySelectorAll('link[rel="stylesheet"]');
+                var swapped = false;
+                links.forEach(function(link) {
+                    var url = new URL(link.href, window.location.href);
+                    if (url.pathname === data.path) {
+                        url.searchParams.set('_lr', Date.now());
+                        link.href = url.toString();
+                        swapped = true;
+                    }
+                });
+                if (!swapped) {
+                    window.location.reload();
+                }
+                return;
+            }
+
+            if (data.kind === 'html' && data.path) {
+                window.location.href = data.path;
+                return;
+            }
+
+            window.location.reload();
+        });
+
+        evtSource.onerror = function() {
+            console.log('Live reload disconnected');
+            evtSource.close();
+            // Try to reconnect every 2 seconds
+            setTimeout(function() {
+                window.location.reload();
+            }, 2000);
+        };
+    })();
+</script>
+`
+
+// defaultErrorTemplate renders a build error as a full HTML page, including
+// the live reload client so the overlay disappears on its own once the
+// source is fixed and the next build succeeds.
+const defaultErrorTemplate = `<!DOCTYPE html>
+<html>
+<head>
+	<title>Build Error</title>
+	<style>
+		body { background: #2b0000; color: #ffb3b3; font-family: monospace; padding: 2rem; }
+		h1 { color: #ff4d4d; }
+		pre { white-space: pre-wrap; background: #3d0000; padding: 1rem; border-radius: 4px; border: 1px solid #ff4d4d; }
+	</style>
+</head>
+<body>
+	<h1>Build failed</h1>
+	<pre>{{.}}</pre>
+	` + liveReloadScript + `
+</body>
+</html>
+`
+
+// serveBuildError renders the current build error overlay.
+func (s *Server) serveBuildError(w http.ResponseWriter, err error) {
+	w.Header().Set("Content-Type", "text/html")
+	if tmplErr := s.errorTmpl.Execute(w, err.Error()); tmplErr != nil {
+		http.Error(w, tmplErr.Error(), http.StatusInternalServerError)
+	}
+}
+
+// filePathToURLPath converts an absolute filesystem path inside dir to the
+// URL path it's served under, e.g. dir=/a/b, path=/a/b/css/site.css -> /css/site.css.
+func filePathToURLPath(dir, path string) string {
+	rel, err := filepath.Rel(dir, path)
+	if err != nil {
+		return "/"
+	}
+	return "/" + filepath.ToSlash(rel)
+}
+
+// injectLiveReload modifies HTML files to include the EventSource client
+func injectLiveReload(w http.ResponseWriter, path string) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		http.Error(w, "Failed to read file", http.StatusInternalServerError)
+		return
+	}
+
+	// Insert the script before the closing </body> tag
+	htmlStr := string(content)
+	if strings.Contains(htmlStr, "</body>") {
+		htmlStr = strings.Replace(htmlStr, "</body>", liveReloadScript+"</body>", 1)
+	} else if strings.Contains(htmlStr, "</html>") {
+		// If no body tag, try html tag
+		htmlStr = strings.Replace(htmlStr, "</html>", liveReloadScript+"</html>", 1)
+	} else {
+		// If no body or html tag, append to the end
+		htmlStr = htmlStr + liveReloadScript
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	w.Write([]byte(htmlStr))
+}
+
+// Handler serves the configured directory with live reload injected into
+// HTML pages.
+func (s *Server) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Serve the error overlay for every request until the next
+		// successful build.
+		if err := s.currentBuildErr(); err != nil {
+			s.serveBuildError(w, err)
+			return
+		}
+
+		// Apply rewrite/redirect rules before resolving the filesystem path.
+		reqPath := r.URL.Path
+		if target, status, ok := s.findRewrite(reqPath); ok {
+			if status != http.StatusOK {
+				http.Redirect(w, r, target, status)
+				return
+			}
+			reqPath = target
+			r = r.Clone(r.Context())
+			r.URL.Path = target
+			r.URL.RawPath = ""
+		}
+
+		// Convert path to filepath
+		path := filepath.Join(s.dir, filepath.Clean(reqPath))
+
+		// Handle root path
+		if reqPath == "/" {
+			// Try to find index.html
+			indexPath := filepath.Join(s.dir, "index.html")
+			if _, err := os.Stat(indexPath); err == nil {
+				path = indexPath
+			}
+		}
+
+		// Check if file exists
+		info, err := os.Stat(path)
+		if err != nil {
+			// SPA fallback: serve index.html with a 200 for client-routed apps.
+			if s.spa {
+				indexPath := filepath.Join(s.dir, "index.html")
+				if _, err := os.Stat(indexPath); err == nil {
+					injectLiveReload(w, indexPath)
+					return
+				}
+			}
+			http.NotFound(w, r)
+			return
+		}
+
+		// If it's a directory, look for index.html
+		if info.IsDir() {
+			indexPath := filepath.Join(path, "index.html")
+			if _, err := os.Stat(indexPath); err == nil {
+				path = indexPath
+			} else {
+				// Try to serve directory listing
+				http.FileServer(http.Dir(s.dir)).ServeHTTP(w, r)
+				return
+			}
+		}
+
+		// Inject live reload for HTML files
+		if strings.HasSuffix(strings.ToLower(path), ".html") {
+			injectLiveReload(w, path)
+			return
+		}
+
+		// Serve other files directly
+		http.FileServer(http.Dir(s.dir)).ServeHTTP(w, r)
+	})
+}
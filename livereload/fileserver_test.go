@@ -0,0 +1,51 @@
+package livereload
+
+import (
+	"errors"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFilePathToURLPath(t *testing.T) {
+	got := filePathToURLPath("/a/b", filepath.Join("/a/b", "css", "site.css"))
+	if got != "/css/site.css" {
+		t.Errorf("filePathToURLPath() = %q, want /css/site.css", got)
+	}
+}
+
+func TestReloadEventForPath(t *testing.T) {
+	cases := map[string]string{
+		"/css/site.css": "css",
+		"/index.html":   "html",
+		"/about.htm":    "html",
+		"/app.js":       "reload",
+	}
+
+	for path, wantKind := range cases {
+		if got := reloadEventForPath(path).Kind; got != wantKind {
+			t.Errorf("reloadEventForPath(%q).Kind = %q, want %q", path, got, wantKind)
+		}
+	}
+}
+
+func TestHandlerServesErrorOverlay(t *testing.T) {
+	dir := t.TempDir()
+	mustWriteFile(t, filepath.Join(dir, "index.html"), "<html><body></body></html>")
+
+	s := New(WithDir(dir))
+	s.setBuildErr(errors.New("boom: line 3: unexpected token"))
+
+	req := httptest.NewRequest("GET", "/", nil)
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+
+	body := rr.Body.String()
+	if !strings.Contains(body, "boom: line 3: unexpected token") {
+		t.Errorf("expected error overlay to contain build error, got %q", body)
+	}
+	if !strings.Contains(body, "EventSource") {
+		t.Errorf("expected error overlay to include the live reload client")
+	}
+}
@@ -0,0 +1,132 @@
+package livereload
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// defaultIgnorePatterns are always applied, matching the previous
+// hard-coded "skip dotfiles and node_modules" behavior.
+var defaultIgnorePatterns = []string{".*", "node_modules"}
+
+// Ignorer matches paths against a set of gitignore-style ignore patterns,
+// with an allow-list of patterns that override them. It's used by the file
+// walker and the fsnotify watcher's path filter.
+type Ignorer struct {
+	ignore  []ignorePattern
+	include []ignorePattern
+}
+
+// ignorePattern is a single compiled gitignore-style pattern, split into
+// path segments. A bare "**" segment matches zero or more path segments.
+type ignorePattern struct {
+	segments []string
+}
+
+// compileIgnorePattern parses a single gitignore-style line. Patterns
+// without a slash (other than a trailing one) match at any depth, like
+// gitignore; patterns with an interior slash are anchored to the root.
+func compileIgnorePattern(raw string) ignorePattern {
+	raw = strings.TrimSuffix(raw, "/")
+	anchored := strings.HasPrefix(raw, "/")
+	raw = strings.TrimPrefix(raw, "/")
+
+	segments := strings.Split(raw, "/")
+	if !anchored && len(segments) == 1 {
+		segments = append([]string{"**"}, segments...)
+	}
+
+	return ignorePattern{segments: segments}
+}
+
+// NewIgnorer compiles ignore and include pattern lists. Blank lines and
+// comments (lines starting with "#") are skipped, matching .gitignore.
+func NewIgnorer(ignore, include []string) *Ignorer {
+	ig := &Ignorer{}
+
+	for _, p := range ignore {
+		if pat, ok := compileIgnoreLine(p); ok {
+			ig.ignore = append(ig.ignore, pat)
+		}
+	}
+	for _, p := range include {
+		if pat, ok := compileIgnoreLine(p); ok {
+			ig.include = append(ig.include, pat)
+		}
+	}
+
+	return ig
+}
+
+func compileIgnoreLine(raw string) (ignorePattern, bool) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" || strings.HasPrefix(raw, "#") {
+		return ignorePattern{}, false
+	}
+	return compileIgnorePattern(raw), true
+}
+
+// Match reports whether the slash-separated relative path should be
+// ignored: it matches an ignore pattern and no include pattern overrides it.
+func (ig *Ignorer) Match(path string) bool {
+	segments := strings.Split(path, "/")
+
+	for _, pat := range ig.include {
+		if matchSegments(pat.segments, segments) {
+			return false
+		}
+	}
+	for _, pat := range ig.ignore {
+		if matchSegments(pat.segments, segments) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchSegments matches pattern segments against path segments, where a
+// "**" segment matches zero or more path segments (the path/filepath.Match
+// extension this package needs beyond filepath.Match's single-segment glob).
+func matchSegments(pattern, path []string) bool {
+	if len(pattern) == 0 {
+		return len(path) == 0
+	}
+
+	if pattern[0] == "**" {
+		if len(pattern) == 1 {
+			return true
+		}
+		for i := 0; i <= len(path); i++ {
+			if matchSegments(pattern[1:], path[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+
+	if len(path) == 0 {
+		return false
+	}
+
+	ok, err := filepath.Match(pattern[0], path[0])
+	if err != nil || !ok {
+		return false
+	}
+
+	return matchSegments(pattern[1:], path[1:])
+}
+
+// loadIgnoreFile reads gitignore-style lines from path, returning nil
+// (not an error) if the file doesn't exist.
+func loadIgnoreFile(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return strings.Split(string(content), "\n"), nil
+}
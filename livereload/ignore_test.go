@@ -0,0 +1,49 @@
+package livereload
+
+import "testing"
+
+func TestIgnorerMatch(t *testing.T) {
+	// Matching is checked against each path as the walker visits it, so a
+	// directory pattern only needs to match the directory entry itself —
+	// filepath.Walk never descends into it once Match reports true.
+	ig := NewIgnorer([]string{"*.log", "/dist", "target/", "**/fixtures/*.json"}, nil)
+
+	cases := map[string]bool{
+		"app.log":                  true,
+		"src/app.log":              true,
+		"dist":                     true,
+		"build/dist":               false,
+		"target":                   true,
+		"src/target":               true,
+		"src/fixtures/a.json":      true,
+		"deep/src/fixtures/a.json": true,
+		"src/fixtures/a.txt":       false,
+		"main.go":                  false,
+	}
+
+	for path, want := range cases {
+		if got := ig.Match(path); got != want {
+			t.Errorf("Match(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestIgnorerIncludeOverridesIgnore(t *testing.T) {
+	ignored := NewIgnorer([]string{"dist"}, nil)
+	if !ignored.Match("dist") {
+		t.Errorf("expected dist to be ignored")
+	}
+
+	overridden := NewIgnorer([]string{"dist"}, []string{"dist"})
+	if overridden.Match("dist") {
+		t.Errorf("expected the include pattern to override the ignore rule")
+	}
+}
+
+func TestIgnorerSkipsBlankLinesAndComments(t *testing.T) {
+	ig := NewIgnorer([]string{"", "  ", "# a comment", "*.tmp"}, nil)
+
+	if !ig.Match("foo.tmp") {
+		t.Errorf("expected *.tmp to still match")
+	}
+}
@@ -0,0 +1,170 @@
+// Package livereload implements an embeddable live-reload dev server: a
+// file watcher that pushes reload events over Server-Sent Events to HTML
+// pages it serves, in the spirit of lrserver/hugo's development server.
+package livereload
+
+import (
+	"html/template"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// BuildFunc runs a build step and reports whether it succeeded. A non-nil
+// build is run before every watch cycle; while it's failing, requests are
+// served the error overlay instead of files.
+type BuildFunc func() error
+
+// ShellBuildFunc returns a BuildFunc that runs cmd through the shell,
+// forwarding its output to this process's stdout/stderr.
+func ShellBuildFunc(cmd string) BuildFunc {
+	return func() error {
+		c := exec.Command("sh", "-c", cmd)
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		return c.Run()
+	}
+}
+
+// Server watches a directory and serves it with live reload injected into
+// HTML pages. Mount it alongside other routes via Handler and EventsHandler,
+// or run it standalone as cmd/gosrv does.
+type Server struct {
+	dir       string
+	interval  time.Duration
+	poll      bool
+	build     BuildFunc
+	errorTmpl *template.Template
+
+	ignorePatterns  []string
+	includePatterns []string
+	ignorer         *Ignorer
+
+	spa      bool
+	rewrites []RewriteRule
+
+	clients   map[chan reloadEvent]bool
+	clientsMu sync.Mutex
+
+	buildMu  sync.Mutex
+	buildErr error
+}
+
+// Option configures a Server constructed with New.
+type Option func(*Server)
+
+// WithDir sets the directory to serve and watch. Defaults to ".".
+func WithDir(dir string) Option {
+	return func(s *Server) { s.dir = dir }
+}
+
+// WithPollInterval makes Watch poll the directory for changes on interval
+// instead of using fsnotify. Useful on filesystems where inotify is
+// unavailable (network mounts, some containers).
+func WithPollInterval(interval time.Duration) Option {
+	return func(s *Server) {
+		s.poll = true
+		s.interval = interval
+	}
+}
+
+// WithBuild sets a build step to run before serving. While it's failing,
+// Handler serves an error overlay instead of files.
+func WithBuild(build BuildFunc) Option {
+	return func(s *Server) { s.build = build }
+}
+
+// WithErrorTemplate overrides the default build-error overlay template.
+// It receives the build error message as {{.}}.
+func WithErrorTemplate(tmpl *template.Template) Option {
+	return func(s *Server) { s.errorTmpl = tmpl }
+}
+
+// WithIgnore adds gitignore-style patterns (in addition to the built-in
+// ".*"/"node_modules" defaults and any .gitignore/.gosrvignore found in
+// the served directory) that the file walker and watcher should skip.
+func WithIgnore(patterns ...string) Option {
+	return func(s *Server) { s.ignorePatterns = append(s.ignorePatterns, patterns...) }
+}
+
+// WithInclude adds gitignore-style patterns that override ignores, e.g. to
+// watch a single gitignored subtree.
+func WithInclude(patterns ...string) Option {
+	return func(s *Server) { s.includePatterns = append(s.includePatterns, patterns...) }
+}
+
+// WithSPA makes Handler serve index.html with a 200 for any request that
+// doesn't match a file on disk, instead of a 404, for client-routed apps.
+func WithSPA(spa bool) Option {
+	return func(s *Server) { s.spa = spa }
+}
+
+// WithRewrites adds URL rewrite/redirect rules, evaluated in order before
+// the filesystem lookup. See ParseRewriteRules for the file format.
+func WithRewrites(rules ...RewriteRule) Option {
+	return func(s *Server) { s.rewrites = append(s.rewrites, rules...) }
+}
+
+// New constructs a Server. Call Watch to start watching for changes, and
+// mount Handler and EventsHandler on a mux to serve it.
+func New(opts ...Option) *Server {
+	s := &Server{
+		dir:       ".",
+		interval:  500 * time.Millisecond,
+		errorTmpl: template.Must(template.New("error").Parse(defaultErrorTemplate)),
+		clients:   make(map[chan reloadEvent]bool),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	ignore := append([]string{}, defaultIgnorePatterns...)
+	ignore = append(ignore, s.ignorePatterns...)
+	for _, name := range []string{".gitignore", ".gosrvignore"} {
+		lines, err := loadIgnoreFile(filepath.Join(s.dir, name))
+		if err == nil {
+			ignore = append(ignore, lines...)
+		}
+	}
+	s.ignorer = NewIgnorer(ignore, s.includePatterns)
+
+	return s
+}
+
+// setBuildErr records the result of the most recent build.
+func (s *Server) setBuildErr(err error) {
+	s.buildMu.Lock()
+	s.buildErr = err
+	s.buildMu.Unlock()
+}
+
+// currentBuildErr returns the error from the most recent build, if any.
+func (s *Server) currentBuildErr() error {
+	s.buildMu.Lock()
+	defer s.buildMu.Unlock()
+	return s.buildErr
+}
+
+// runBuildAndNotify runs the build step, if configured, before notifying
+// clients of urlPaths. A failed build is cached so Handler can serve the
+// error overlay, and clients are still pushed a reload so they pick it up;
+// a successful build clears the cached error and notifies clients of the
+// real changes as usual.
+func (s *Server) runBuildAndNotify(urlPaths ...string) {
+	if s.build == nil {
+		s.Notify(urlPaths...)
+		return
+	}
+
+	if err := s.build(); err != nil {
+		s.setBuildErr(err)
+		s.Notify()
+		return
+	}
+
+	s.setBuildErr(nil)
+	s.Notify(urlPaths...)
+}
@@ -0,0 +1,87 @@
+package livereload
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RewriteRule is a single entry from a -rewrites file, in the simple
+// "from -> to [status]" format (similar to Netlify's _redirects). Status
+// defaults to 200, meaning serve to's content at from's URL; any other
+// status issues an HTTP redirect to to instead.
+type RewriteRule struct {
+	From   string
+	To     string
+	Status int
+}
+
+// match reports whether path matches the rule, returning the resolved
+// target. A trailing "*" on From makes it a prefix match; if To also ends
+// in "*", the matched suffix is appended to it.
+func (r RewriteRule) match(path string) (string, bool) {
+	if !strings.HasSuffix(r.From, "*") {
+		return r.To, path == r.From
+	}
+
+	prefix := strings.TrimSuffix(r.From, "*")
+	if !strings.HasPrefix(path, prefix) {
+		return "", false
+	}
+
+	to := r.To
+	if strings.HasSuffix(to, "*") {
+		to = strings.TrimSuffix(to, "*") + strings.TrimPrefix(path, prefix)
+	}
+	return to, true
+}
+
+// ParseRewriteRules parses a -rewrites file's contents: one "from -> to
+// [status]" rule per line, blank lines and "#" comments ignored, evaluated
+// in file order.
+func ParseRewriteRules(content string) ([]RewriteRule, error) {
+	var rules []RewriteRule
+
+	for i, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, "->", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("rewrites line %d: expected \"from -> to [status]\", got %q", i+1, line)
+		}
+
+		from := strings.TrimSpace(parts[0])
+		rest := strings.Fields(parts[1])
+		if len(rest) == 0 {
+			return nil, fmt.Errorf("rewrites line %d: missing target", i+1)
+		}
+
+		status := http.StatusOK
+		if len(rest) > 1 {
+			s, err := strconv.Atoi(rest[1])
+			if err != nil {
+				return nil, fmt.Errorf("rewrites line %d: invalid status %q", i+1, rest[1])
+			}
+			status = s
+		}
+
+		rules = append(rules, RewriteRule{From: from, To: rest[0], Status: status})
+	}
+
+	return rules, nil
+}
+
+// findRewrite returns the target and status of the first rule matching
+// path, evaluated in priority (file) order.
+func (s *Server) findRewrite(path string) (target string, status int, ok bool) {
+	for _, rule := range s.rewrites {
+		if to, matched := rule.match(path); matched {
+			return to, rule.Status, true
+		}
+	}
+	return "", 0, false
+}
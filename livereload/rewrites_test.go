@@ -0,0 +1,122 @@
+package livereload
+
+import (
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseRewriteRules(t *testing.T) {
+	rules, err := ParseRewriteRules(`
+# comment
+/old -> /new 301
+/api/* -> /api/v2/* 200
+/plain -> /target
+
+`)
+	if err != nil {
+		t.Fatalf("ParseRewriteRules() error = %v", err)
+	}
+	if len(rules) != 3 {
+		t.Fatalf("got %d rules, want 3", len(rules))
+	}
+	if rules[0] != (RewriteRule{From: "/old", To: "/new", Status: 301}) {
+		t.Errorf("rules[0] = %+v", rules[0])
+	}
+	if rules[2].Status != 200 {
+		t.Errorf("expected default status 200, got %d", rules[2].Status)
+	}
+}
+
+func TestParseRewriteRulesRejectsMalformedLine(t *testing.T) {
+	if _, err := ParseRewriteRules("not a rule"); err == nil {
+		t.Error("expected an error for a line without \"->\"")
+	}
+}
+
+func TestRewriteRuleMatch(t *testing.T) {
+	wildcard := RewriteRule{From: "/api/*", To: "/api/v2/*"}
+	if to, ok := wildcard.match("/api/users"); !ok || to != "/api/v2/users" {
+		t.Errorf("match(/api/users) = (%q, %v), want (/api/v2/users, true)", to, ok)
+	}
+	if _, ok := wildcard.match("/other"); ok {
+		t.Error("expected /other not to match /api/*")
+	}
+
+	exact := RewriteRule{From: "/old", To: "/new"}
+	if to, ok := exact.match("/old"); !ok || to != "/new" {
+		t.Errorf("match(/old) = (%q, %v), want (/new, true)", to, ok)
+	}
+	if _, ok := exact.match("/old/nested"); ok {
+		t.Error("expected exact rule not to match a nested path")
+	}
+}
+
+func TestHandlerSPAFallback(t *testing.T) {
+	dir := t.TempDir()
+	mustWriteFile(t, filepath.Join(dir, "index.html"), "<html><body>app</body></html>")
+
+	s := New(WithDir(dir), WithSPA(true))
+
+	req := httptest.NewRequest("GET", "/dashboard/settings", nil)
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != 200 {
+		t.Errorf("status = %d, want 200", rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), "app") {
+		t.Errorf("expected index.html content in body, got %q", rr.Body.String())
+	}
+}
+
+func TestHandlerSPAFallbackDisabledServes404(t *testing.T) {
+	dir := t.TempDir()
+	mustWriteFile(t, filepath.Join(dir, "index.html"), "<html><body>app</body></html>")
+
+	s := New(WithDir(dir))
+
+	req := httptest.NewRequest("GET", "/dashboard/settings", nil)
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != 404 {
+		t.Errorf("status = %d, want 404", rr.Code)
+	}
+}
+
+func TestHandlerAppliesRewriteRule(t *testing.T) {
+	dir := t.TempDir()
+	mustWriteFile(t, filepath.Join(dir, "new.html"), "<html><body>new</body></html>")
+
+	s := New(WithDir(dir), WithRewrites(RewriteRule{From: "/old", To: "/new.html", Status: 200}))
+
+	req := httptest.NewRequest("GET", "/old", nil)
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != 200 {
+		t.Errorf("status = %d, want 200", rr.Code)
+	}
+	if !strings.Contains(rr.Body.String(), "new") {
+		t.Errorf("expected new.html content in body, got %q", rr.Body.String())
+	}
+}
+
+func TestHandlerAppliesRedirectRule(t *testing.T) {
+	dir := t.TempDir()
+
+	s := New(WithDir(dir), WithRewrites(RewriteRule{From: "/old", To: "/new", Status: 301}))
+
+	req := httptest.NewRequest("GET", "/old", nil)
+	rr := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rr, req)
+
+	if rr.Code != 301 {
+		t.Errorf("status = %d, want 301", rr.Code)
+	}
+	if loc := rr.Header().Get("Location"); loc != "/new" {
+		t.Errorf("Location = %q, want /new", loc)
+	}
+}
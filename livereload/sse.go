@@ -0,0 +1,125 @@
+package livereload
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// reloadEvent describes a single changed file to the client script, which
+// uses Kind to decide whether to hot-swap a stylesheet, navigate to a
+// changed page, or fall back to a full reload.
+type reloadEvent struct {
+	Path string `json:"path"`
+	Kind string `json:"kind"`
+}
+
+// reloadEventForPath classifies a changed URL path so the client can decide
+// whether to hot-swap a stylesheet, navigate to a changed page, or reload.
+func reloadEventForPath(urlPath string) reloadEvent {
+	switch strings.ToLower(filepath.Ext(urlPath)) {
+	case ".css":
+		return reloadEvent{Path: urlPath, Kind: "css"}
+	case ".html", ".htm":
+		return reloadEvent{Path: urlPath, Kind: "html"}
+	default:
+		return reloadEvent{Path: urlPath, Kind: "reload"}
+	}
+}
+
+// Notify sends a reload event to all connected clients for each changed URL
+// path. With no paths given, it sends a plain full-reload event.
+func (s *Server) Notify(urlPaths ...string) {
+	events := make([]reloadEvent, 0, len(urlPaths))
+	for _, p := range urlPaths {
+		events = append(events, reloadEventForPath(p))
+	}
+	if len(events) == 0 {
+		events = append(events, reloadEvent{Kind: "reload"})
+	}
+
+	s.clientsMu.Lock()
+	defer s.clientsMu.Unlock()
+
+	for client := range s.clients {
+		for _, ev := range events {
+			// Non-blocking send
+			select {
+			case client <- ev:
+				// Successfully sent
+			default:
+				// Channel full or closed, will be cleaned up on next cycle
+			}
+		}
+	}
+}
+
+// EventsHandler serves the Server-Sent Events stream that the live reload
+// client script connects to.
+func (s *Server) EventsHandler() http.Handler {
+	return http.HandlerFunc(s.handleEventSource)
+}
+
+// handleEventSource is the EventSource handler for live reload
+func (s *Server) handleEventSource(w http.ResponseWriter, r *http.Request) {
+	// Set headers for SSE (Server-Sent Events)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	// Create a channel for this client, buffered so that a batch of events
+	// from one Notify call isn't cut short while the first is being written.
+	messageChan := make(chan reloadEvent, 64)
+
+	// Register new client
+	s.clientsMu.Lock()
+	s.clients[messageChan] = true
+	s.clientsMu.Unlock()
+
+	// Remove client when disconnected
+	defer func() {
+		s.clientsMu.Lock()
+		delete(s.clients, messageChan)
+		close(messageChan)
+		s.clientsMu.Unlock()
+	}()
+
+	// Set a timeout for the connection
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	// Keep connection alive
+	notify := w.(http.CloseNotifier).CloseNotify()
+
+	// Send initial connection message
+	fmt.Fprintf(w, "event: connected\ndata: %d\n\n", time.Now().Unix())
+	flusher.Flush()
+
+	// Wait for messages or connection close
+	for {
+		select {
+		case <-notify:
+			return
+		case ev := <-messageChan:
+			data, err := json.Marshal(ev)
+			if err != nil {
+				log.Println("Error marshaling reload event:", err)
+				continue
+			}
+			fmt.Fprintf(w, "event: reload\ndata: %s\n\n", data)
+			flusher.Flush()
+		case <-time.After(25 * time.Second):
+			// Send keep-alive comment to keep connection open
+			fmt.Fprintf(w, ": keepalive %d\n\n", time.Now().Unix())
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,254 @@
+package livereload
+
+import (
+	"context"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// debounceWindow coalesces bursts of filesystem events (e.g. editors that
+// write a file multiple times per save) into a single reload notification.
+const debounceWindow = 100 * time.Millisecond
+
+// ignoredSuffixes matches temp/swap files written by editors and tools that
+// we never want to trigger a reload for.
+var ignoredSuffixes = []string{"~", ".swp", ".swx", ".tmp", ".DS_Store"}
+
+// ignoredNames matches exact temp filenames used by specific editors/tools.
+var ignoredNames = []string{"4913", "jb_old___", "jb_bak___"}
+
+// shouldIgnoreChange reports whether a changed path is an editor temp file
+// that shouldn't trigger a rebuild/reload.
+func shouldIgnoreChange(path string) bool {
+	name := filepath.Base(path)
+
+	if strings.HasPrefix(name, ".goutputstream") {
+		return true
+	}
+
+	for _, suffix := range ignoredSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+
+	for _, n := range ignoredNames {
+		if name == n {
+			return true
+		}
+	}
+
+	return false
+}
+
+// relSlash returns path relative to s.dir, using forward slashes, for
+// matching against s.ignorer. The root directory itself is "".
+func (s *Server) relSlash(path string) string {
+	rel, err := filepath.Rel(s.dir, path)
+	if err != nil {
+		return ""
+	}
+	if rel == "." {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
+// scanDirectory scans the directory and returns a map of files with their
+// modification times, skipping anything s.ignorer matches.
+func (s *Server) scanDirectory() (map[string]time.Time, error) {
+	fileMap := make(map[string]time.Time)
+
+	err := filepath.Walk(s.dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if rel := s.relSlash(path); rel != "" && s.ignorer.Match(rel) {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		// Store the file's path and modification time
+		fileMap[path] = info.ModTime()
+		return nil
+	})
+
+	return fileMap, err
+}
+
+// addWatchedDirs walks dir and registers every subdirectory not matched by
+// s.ignorer with watcher.
+func (s *Server) addWatchedDirs(watcher *fsnotify.Watcher, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return nil
+		}
+
+		if rel := s.relSlash(path); rel != "" && s.ignorer.Match(rel) {
+			return filepath.SkipDir
+		}
+
+		return watcher.Add(path)
+	})
+}
+
+// Watch watches the configured directory for changes until ctx is canceled,
+// running the build step (if any) and notifying clients on every change. It
+// uses fsnotify unless a poll interval was configured with WithPollInterval.
+func (s *Server) Watch(ctx context.Context) error {
+	if s.build != nil {
+		if err := s.build(); err != nil {
+			log.Println("Build failed:", err)
+			s.setBuildErr(err)
+		}
+	}
+
+	if s.poll {
+		return s.watchPoll(ctx)
+	}
+	return s.watchFsnotify(ctx)
+}
+
+// watchFsnotify watches the configured directory (and any subdirectories
+// created while running) for changes using fsnotify, debouncing bursts of
+// events before running the build step and notifying clients.
+func (s *Server) watchFsnotify(ctx context.Context) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close()
+
+	if err := s.addWatchedDirs(watcher, s.dir); err != nil {
+		return err
+	}
+
+	pending := make(map[string]struct{})
+	var debounce *time.Timer
+	var debounceC <-chan time.Time
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			if shouldIgnoreChange(event.Name) {
+				continue
+			}
+
+			if rel := s.relSlash(event.Name); rel != "" && s.ignorer.Match(rel) {
+				continue
+			}
+
+			// A bare permission-bits change isn't interesting on its own.
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			// Newly created directories need to be registered so files
+			// added inside them are picked up too.
+			if event.Op&fsnotify.Create == fsnotify.Create {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err := s.addWatchedDirs(watcher, event.Name); err != nil {
+						log.Println("Error watching new directory:", err)
+					}
+				}
+			}
+
+			log.Println("File changed:", event.Name)
+			pending[filePathToURLPath(s.dir, event.Name)] = struct{}{}
+
+			if debounce == nil {
+				debounce = time.NewTimer(debounceWindow)
+				debounceC = debounce.C
+			} else {
+				debounce.Reset(debounceWindow)
+			}
+		case <-debounceC:
+			paths := make([]string, 0, len(pending))
+			for p := range pending {
+				paths = append(paths, p)
+			}
+			pending = make(map[string]struct{})
+			debounce = nil
+			debounceC = nil
+
+			s.runBuildAndNotify(paths...)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			log.Println("Watcher error:", err)
+		}
+	}
+}
+
+// watchPoll periodically scans the directory for changes. This is the
+// fallback used when WithPollInterval is set, for filesystems where
+// inotify isn't available (network mounts, some containers).
+func (s *Server) watchPoll(ctx context.Context) error {
+	// Initial scan of the directory
+	prevFiles, err := s.scanDirectory()
+	if err != nil {
+		return err
+	}
+
+	// Periodically scan the directory for changes
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			currentFiles, err := s.scanDirectory()
+			if err != nil {
+				log.Println("Error scanning directory:", err)
+				continue
+			}
+
+			var changed []string
+
+			// Check for new or modified files
+			for path, modTime := range currentFiles {
+				prevModTime, exists := prevFiles[path]
+				if !exists || modTime.After(prevModTime) {
+					log.Println("File changed:", path)
+					changed = append(changed, filePathToURLPath(s.dir, path))
+				}
+			}
+
+			// Check for deleted files
+			for path := range prevFiles {
+				if _, exists := currentFiles[path]; !exists {
+					log.Println("File deleted:", path)
+					changed = append(changed, filePathToURLPath(s.dir, path))
+				}
+			}
+
+			// Update the previous files map
+			prevFiles = currentFiles
+
+			// Notify clients if there were changes
+			if len(changed) > 0 {
+				s.runBuildAndNotify(changed...)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package livereload
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestShouldIgnoreChange(t *testing.T) {
+	cases := map[string]bool{
+		"index.html":           false,
+		"main.go~":             true,
+		"foo.swp":              true,
+		"foo.swx":              true,
+		"style.css.tmp":        true,
+		".DS_Store":            true,
+		"4913":                 true,
+		".goutputstream-XYZ12": true,
+		"jb_old___":            true,
+		"jb_bak___":            true,
+	}
+
+	for name, want := range cases {
+		got := shouldIgnoreChange(filepath.Join("some", "dir", name))
+		if got != want {
+			t.Errorf("shouldIgnoreChange(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestScanDirectorySkipsHiddenAndNodeModules(t *testing.T) {
+	dir := t.TempDir()
+
+	mustWriteFile(t, filepath.Join(dir, "index.html"), "hello")
+	mustWriteFile(t, filepath.Join(dir, ".hidden", "secret.txt"), "shh")
+	mustWriteFile(t, filepath.Join(dir, "node_modules", "pkg", "index.js"), "module.exports={}")
+
+	s := New(WithDir(dir))
+	files, err := s.scanDirectory()
+	if err != nil {
+		t.Fatalf("scanDirectory() error = %v", err)
+	}
+
+	if _, ok := files[filepath.Join(dir, "index.html")]; !ok {
+		t.Errorf("expected index.html to be scanned")
+	}
+	for path := range files {
+		if filepath.Base(filepath.Dir(path)) == ".hidden" || filepath.Base(path) == ".hidden" {
+			t.Errorf("expected hidden directory to be skipped, found %s", path)
+		}
+		if filepath.Base(filepath.Dir(path)) == "node_modules" || filepath.Base(path) == "node_modules" {
+			t.Errorf("expected node_modules to be skipped, found %s", path)
+		}
+	}
+}
+
+func TestServerWatchFsnotifyDebouncesNotifications(t *testing.T) {
+	dir := t.TempDir()
+	s := New(WithDir(dir))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go s.Watch(ctx)
+
+	// Give the watcher a moment to register the directory before writing.
+	time.Sleep(50 * time.Millisecond)
+
+	s.clientsMu.Lock()
+	ch := make(chan reloadEvent, 1)
+	s.clients[ch] = true
+	s.clientsMu.Unlock()
+	defer func() {
+		s.clientsMu.Lock()
+		delete(s.clients, ch)
+		s.clientsMu.Unlock()
+	}()
+
+	mustWriteFile(t, filepath.Join(dir, "index.html"), "hello")
+	mustWriteFile(t, filepath.Join(dir, "index.html"), "hello again")
+
+	select {
+	case ev := <-ch:
+		if ev.Kind != "html" || ev.Path != "/index.html" {
+			t.Errorf("got event %+v, want {Path: /index.html, Kind: html}", ev)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for reload notification")
+	}
+}
+
+func mustWriteFile(t *testing.T, path, contents string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("MkdirAll(%s) error = %v", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, []byte(contents), 0o644); err != nil {
+		t.Fatalf("WriteFile(%s) error = %v", path, err)
+	}
+}